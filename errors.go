@@ -0,0 +1,74 @@
+package lob
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError is returned by Get, Post, Delete, and PostMultipart (and their
+// Context variants) when Lob responds with a non-200 status code. It
+// captures enough of the response for callers to branch on failure modes
+// programmatically instead of matching on a formatted string.
+type APIError struct {
+	// StatusCode is the HTTP status code returned by Lob.
+	StatusCode int
+	// Code is Lob's machine-readable error code, if the response body
+	// included one.
+	Code string
+	// Message is Lob's human-readable error message, if the response body
+	// included one. Otherwise it is the raw response body.
+	Message string
+	// URL is the request URL that produced this error.
+	URL string
+}
+
+// lobErrorBody is the envelope Lob wraps error responses in:
+//
+//	{"error": {"message": "...", "status_code": 422, "code": "..."}}
+type lobErrorBody struct {
+	Error struct {
+		Message    string `json:"message"`
+		StatusCode int    `json:"status_code"`
+		Code       string `json:"code"`
+	} `json:"error"`
+}
+
+// newAPIError builds an APIError from a non-200 response, extracting Lob's
+// structured error message and code from data when possible.
+func newAPIError(statusCode int, url string, data []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		URL:        url,
+		Message:    string(data),
+	}
+
+	var body lobErrorBody
+	if err := json.Unmarshal(data, &body); err == nil && body.Error.Message != "" {
+		apiErr.Message = body.Error.Message
+		apiErr.Code = body.Error.Code
+	}
+
+	return apiErr
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("lob: %s returned status %d: %s", e.URL, e.StatusCode, e.Message)
+}
+
+// IsRateLimited reports whether err is an APIError for an HTTP 429 response.
+func IsRateLimited(err error) bool {
+	return hasStatusCode(err, http.StatusTooManyRequests)
+}
+
+// IsNotFound reports whether err is an APIError for an HTTP 404 response.
+func IsNotFound(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+func hasStatusCode(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
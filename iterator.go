@@ -0,0 +1,138 @@
+package lob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// defaultIterPageSize is used by an Iterator whose PageSize is left at zero.
+const defaultIterPageSize = 100
+
+// PageInfo carries the pagination metadata returned alongside a page of
+// results from a Lob list endpoint.
+type PageInfo struct {
+	// Count is the number of items in the most recently fetched page.
+	Count int
+	// TotalCount is the total number of items across all pages, as
+	// reported by Lob.
+	TotalCount int
+}
+
+// page is the envelope Lob wraps list results in.
+type page[T any] struct {
+	Data       []T    `json:"data"`
+	Count      int    `json:"count"`
+	TotalCount int    `json:"total_count"`
+	NextURL    string `json:"next_url"`
+}
+
+// Iterator walks a Lob paginated list endpoint one item at a time, fetching
+// pages on demand via Get.
+type Iterator[T any] struct {
+	lob      *Lob
+	endpoint string
+	params   map[string]string
+	pageSize int
+
+	offset  int
+	items   []T
+	index   int
+	done    bool
+	lastErr error
+	info    PageInfo
+}
+
+// newIterator builds an Iterator over endpoint, adding offset/limit query
+// params derived from pageSize to the caller-supplied params on each page
+// fetch.
+func newIterator[T any](lob *Lob, endpoint string, params map[string]string, pageSize int) *Iterator[T] {
+	if pageSize <= 0 {
+		pageSize = defaultIterPageSize
+	}
+	return &Iterator[T]{
+		lob:      lob,
+		endpoint: endpoint,
+		params:   params,
+		pageSize: pageSize,
+	}
+}
+
+// PageInfo returns the count/total_count metadata from the most recently
+// fetched page.
+func (it *Iterator[T]) PageInfo() PageInfo {
+	return it.info
+}
+
+// Next returns the next item, fetching additional pages from Lob as needed.
+// It returns io.EOF once the list is exhausted, or an error wrapping
+// ctx's error if ctx is canceled while waiting on a page fetch.
+func (it *Iterator[T]) Next(ctx context.Context) (T, error) {
+	var zero T
+
+	for it.index >= len(it.items) {
+		if it.done {
+			return zero, io.EOF
+		}
+		if err := it.fetch(ctx); err != nil {
+			return zero, err
+		}
+	}
+
+	item := it.items[it.index]
+	it.index++
+	return item, nil
+}
+
+func (it *Iterator[T]) fetch(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	params := make(map[string]string, len(it.params)+2)
+	for k, v := range it.params {
+		params[k] = v
+	}
+	params["limit"] = strconv.Itoa(it.pageSize)
+	params["offset"] = strconv.Itoa(it.offset)
+
+	var p page[T]
+	if err := it.lob.GetContext(ctx, it.endpoint, params, &p); err != nil {
+		it.done = true
+		return fmt.Errorf("fetching page at offset %d: %w", it.offset, err)
+	}
+
+	it.info = PageInfo{Count: p.Count, TotalCount: p.TotalCount}
+	it.items = p.Data
+	it.index = 0
+	it.offset += len(p.Data)
+
+	if len(p.Data) < it.pageSize || p.NextURL == "" {
+		it.done = true
+	}
+	if len(p.Data) == 0 {
+		it.done = true
+		return io.EOF
+	}
+	return nil
+}
+
+// ListAddressesIter returns an Iterator over the addresses endpoint,
+// fetching pageSize addresses per underlying request (0 uses a default).
+func ListAddressesIter(lob *Lob, params map[string]string, pageSize int) *Iterator[Address] {
+	return newIterator[Address](lob, "addresses", params, pageSize)
+}
+
+// ListChecksIter returns an Iterator over the checks endpoint, fetching
+// pageSize checks per underlying request (0 uses a default).
+func ListChecksIter(lob *Lob, params map[string]string, pageSize int) *Iterator[Check] {
+	return newIterator[Check](lob, "checks", params, pageSize)
+}
+
+// ListBankAccountsIter returns an Iterator over the bank accounts endpoint,
+// fetching pageSize bank accounts per underlying request (0 uses a
+// default).
+func ListBankAccountsIter(lob *Lob, params map[string]string, pageSize int) *Iterator[BankAccount] {
+	return newIterator[BankAccount](lob, "bank_accounts", params, pageSize)
+}
@@ -0,0 +1,147 @@
+package lob
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type testItem struct {
+	ID string `json:"id"`
+}
+
+// newTestPageServer serves pages of testItems, pageSize items at a time,
+// reading offset/limit from the query string the way Lob's list endpoints
+// do.
+func newTestPageServer(t *testing.T, total int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit := 100
+		if v := r.URL.Query().Get("limit"); v != "" {
+			fmt.Sscanf(v, "%d", &limit)
+		}
+		offset := 0
+		if v := r.URL.Query().Get("offset"); v != "" {
+			fmt.Sscanf(v, "%d", &offset)
+		}
+
+		end := offset + limit
+		if end > total {
+			end = total
+		}
+		if offset > total {
+			offset = total
+		}
+
+		items := make([]testItem, 0, end-offset)
+		for i := offset; i < end; i++ {
+			items = append(items, testItem{ID: fmt.Sprintf("item_%d", i)})
+		}
+
+		nextURL := ""
+		if end < total {
+			nextURL = "/items?offset=" + fmt.Sprint(end)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data":        items,
+			"count":       len(items),
+			"total_count": total,
+			"next_url":    nextURL,
+		})
+	}))
+}
+
+func drain(t *testing.T, it *Iterator[testItem]) []testItem {
+	t.Helper()
+	var got []testItem
+	for {
+		item, err := it.Next(context.Background())
+		if err == io.EOF {
+			return got
+		}
+		if err != nil {
+			t.Fatalf("Next() returned unexpected error: %v", err)
+		}
+		got = append(got, item)
+	}
+}
+
+func TestIteratorPaginatesAcrossMultiplePages(t *testing.T) {
+	srv := newTestPageServer(t, 9)
+	defer srv.Close()
+	lob := &Lob{BaseAPI: srv.URL + "/", APIKey: "test"}
+
+	it := newIterator[testItem](lob, "items", nil, 4)
+	got := drain(t, it)
+
+	if len(got) != 9 {
+		t.Fatalf("got %d items, want 9", len(got))
+	}
+	for i, item := range got {
+		want := fmt.Sprintf("item_%d", i)
+		if item.ID != want {
+			t.Errorf("item %d = %q, want %q", i, item.ID, want)
+		}
+	}
+}
+
+func TestIteratorTerminatesOnEmptyList(t *testing.T) {
+	srv := newTestPageServer(t, 0)
+	defer srv.Close()
+	lob := &Lob{BaseAPI: srv.URL + "/", APIKey: "test"}
+
+	it := newIterator[testItem](lob, "items", nil, 4)
+	if _, err := it.Next(context.Background()); err != io.EOF {
+		t.Fatalf("Next() on an empty list = %v, want io.EOF", err)
+	}
+}
+
+func TestIteratorTerminatesOnExactPageBoundary(t *testing.T) {
+	// total is an exact multiple of pageSize: the last page is full but
+	// has no next_url, which must still stop the iterator rather than
+	// fetching an empty page forever.
+	srv := newTestPageServer(t, 8)
+	defer srv.Close()
+	lob := &Lob{BaseAPI: srv.URL + "/", APIKey: "test"}
+
+	it := newIterator[testItem](lob, "items", nil, 4)
+	got := drain(t, it)
+	if len(got) != 8 {
+		t.Fatalf("got %d items, want 8", len(got))
+	}
+}
+
+func TestIteratorPageInfoReflectsLastFetchedPage(t *testing.T) {
+	srv := newTestPageServer(t, 9)
+	defer srv.Close()
+	lob := &Lob{BaseAPI: srv.URL + "/", APIKey: "test"}
+
+	it := newIterator[testItem](lob, "items", nil, 4)
+	if _, err := it.Next(context.Background()); err != nil {
+		t.Fatalf("Next() returned error: %v", err)
+	}
+	if info := it.PageInfo(); info.Count != 4 || info.TotalCount != 9 {
+		t.Errorf("PageInfo() = %+v, want Count=4 TotalCount=9", info)
+	}
+}
+
+func TestIteratorRespectsCanceledContext(t *testing.T) {
+	srv := newTestPageServer(t, 9)
+	defer srv.Close()
+	lob := &Lob{BaseAPI: srv.URL + "/", APIKey: "test"}
+
+	it := newIterator[testItem](lob, "items", nil, 4)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := it.Next(ctx); err == nil {
+		t.Fatal("Next() with a canceled context should return an error")
+	}
+}
@@ -0,0 +1,51 @@
+package lob
+
+import (
+	"context"
+	"time"
+)
+
+// Letter represents a letter created through the Lob API.
+type Letter struct {
+	ID                   string            `json:"id"`
+	Description          string            `json:"description"`
+	To                   string            `json:"to"`
+	From                 string            `json:"from"`
+	Color                bool              `json:"color"`
+	DoubleSidedPrinting  bool              `json:"double_sided_printing"`
+	URL                  string            `json:"url"`
+	ExpectedDeliveryDate string            `json:"expected_delivery_date"`
+	Metadata             map[string]string `json:"metadata"`
+}
+
+// CreateLetterParams are the form fields accepted when creating a letter.
+// File holds the letter's PDF/HTML content; see PostMultipart.
+type CreateLetterParams struct {
+	Description         string            `json:"description"`
+	To                  string            `json:"to"`
+	From                string            `json:"from"`
+	Color               *bool             `json:"color"`
+	DoubleSidedPrinting *bool             `json:"double_sided_printing"`
+	Metadata            map[string]string `json:"metadata"`
+	File                FileUpload        `json:"-"`
+}
+
+// CreateLetter creates a letter from params, uploading params.File as the
+// letter's content.
+func (lob *Lob) CreateLetter(params CreateLetterParams) (*Letter, error) {
+	return lob.CreateLetterContext(context.Background(), params)
+}
+
+// CreateLetterContext is CreateLetter with an explicit context for
+// cancellation and deadlines.
+func (lob *Lob) CreateLetterContext(ctx context.Context, params CreateLetterParams) (*Letter, error) {
+	start := time.Now()
+	formParams := json2form(params)
+	var letter Letter
+	err := lob.PostMultipartContext(ctx, "letters", formParams, map[string]FileUpload{"file": params.File}, &letter)
+	Metrics.CreateLetter.Observe(time.Since(start), err == nil)
+	if err != nil {
+		return nil, err
+	}
+	return &letter, nil
+}
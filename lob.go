@@ -2,37 +2,72 @@ package lob
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"reflect"
 	"runtime"
 	"strconv"
 	"strings"
-
-	"github.com/op/go-logging"
+	"time"
 )
 
-var log = logging.MustGetLogger("lob")
+// logger returns the slog.Logger to use for this Lob, falling back to
+// slog.Default() when the caller hasn't supplied one.
+func (lob *Lob) logger() *slog.Logger {
+	if lob.Logger != nil {
+		return lob.Logger
+	}
+	return slog.Default()
+}
 
-// LogStackTrace logs a stack trace for the given error.
-func logStackTrace(err error) {
-	buf := make([]byte, 0, 16384)
+// logStackTrace logs a stack trace for the given error.
+func (lob *Lob) logStackTrace(err error) {
+	buf := make([]byte, 16384)
 	n := runtime.Stack(buf, false)
 	if err != nil {
-		log.Errorf("Non-nil error %s; stack trace %s", err.Error(), buf[:n])
+		lob.logger().Error("lob request failed", "error", err, "stack", string(buf[:n]))
 	} else {
-		log.Errorf("Nil error; stack trace %s", buf[:n])
+		lob.logger().Error("lob request failed with nil error", "stack", string(buf[:n]))
 	}
 }
 
+// DefaultTimeout is the timeout applied to requests made through a Lob
+// whose HTTPClient is nil, when the caller's context has no deadline.
+const DefaultTimeout = 30 * time.Second
+
 // Lob represents information on how to connect to the lob.com API.
 type Lob struct {
 	BaseAPI string
 	APIKey  string
+
+	// HTTPClient is used to make requests. If nil, a plain http.Client is
+	// used and each request is bounded by the DefaultTimeout field. A
+	// non-nil HTTPClient's own Timeout is the only bound applied.
+	HTTPClient *http.Client
+
+	// DefaultTimeout bounds how long a request may take when HTTPClient is
+	// nil and the caller's context has no deadline of its own. Zero means
+	// the DefaultTimeout constant is used.
+	DefaultTimeout time.Duration
+
+	// RetryPolicy controls automatic retries of failed POST requests. The
+	// zero value disables retries.
+	RetryPolicy *RetryPolicy
+
+	// Logger receives request/response logging. If nil, slog.Default() is
+	// used.
+	Logger *slog.Logger
+
+	// Transport chains http.RoundTripper middlewares around the underlying
+	// client's transport, applied in order (the first wraps closest to the
+	// network). See Chain.
+	Transport []Middleware
 }
 
 // Base URL and API version for Lob.
@@ -58,6 +93,8 @@ type MetricsSet struct {
 	ListAddresses     *MetricsBundle
 	GetStates         *MetricsBundle
 	GetCountries      *MetricsBundle
+	CreateLetter      *MetricsBundle
+	CreatePostcard    *MetricsBundle
 }
 
 // Metrics is the set of metrics for this API.
@@ -80,6 +117,8 @@ func init() {
 		ListAddresses:     NewMetricsBundle("address_list"),
 		GetStates:         NewMetricsBundle("states_list"),
 		GetCountries:      NewMetricsBundle("countries_list"),
+		CreateLetter:      NewMetricsBundle("letter_create"),
+		CreatePostcard:    NewMetricsBundle("postcard_create"),
 	}
 }
 
@@ -110,6 +149,9 @@ func json2form(v interface{}) map[string]string {
 	for i := 0; i < value.NumField(); i++ {
 		f := t.Field(i)
 		name := f.Tag.Get("json")
+		if name == "-" {
+			continue
+		}
 		fv := value.Field(i).Interface()
 		if fv == nil {
 			continue
@@ -153,13 +195,63 @@ func json2form(v interface{}) map[string]string {
 	return params
 }
 
+// httpClient returns the client to use for requests, with lob.Transport's
+// middlewares wrapped around its RoundTripper. DefaultTimeout is enforced
+// per-request via contextWithTimeout rather than as a Client.Timeout, so it
+// only applies when the caller's context has no deadline of its own.
+func (lob *Lob) httpClient() *http.Client {
+	client := lob.HTTPClient
+	if client == nil {
+		client = &http.Client{}
+	}
+	if len(lob.Transport) == 0 {
+		return client
+	}
+
+	rt := client.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	wrapped := *client
+	wrapped.Transport = Chain(lob.Transport...)(rt)
+	return &wrapped
+}
+
+// contextWithTimeout bounds ctx by DefaultTimeout when ctx has no deadline of
+// its own, so a caller-supplied deadline always wins over it. It is a no-op
+// when the caller has configured a custom HTTPClient, since that client's own
+// Timeout (if any) already governs the request. The returned cancel must be
+// called once the request is done, typically via defer.
+func (lob *Lob) contextWithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if lob.HTTPClient != nil {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	timeout := lob.DefaultTimeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
 // Get performs a GET request to the Lob API.
 func (lob *Lob) Get(endpoint string, params map[string]string, returnValue interface{}) error {
+	return lob.GetContext(context.Background(), endpoint, params, returnValue)
+}
+
+// GetContext performs a GET request to the Lob API, honoring cancellation
+// and deadlines carried by ctx.
+func (lob *Lob) GetContext(ctx context.Context, endpoint string, params map[string]string, returnValue interface{}) error {
+	ctx, cancel := lob.contextWithTimeout(ctx)
+	defer cancel()
+
 	fullURL := lob.BaseAPI + endpoint + queryParams(params)
-	log.Debugf("Lob GET %s", fullURL)
-	req, err := http.NewRequest("GET", fullURL, nil)
+	lob.logger().Debug("Lob GET", "url", fullURL)
+	req, err := http.NewRequestWithContext(ctx, "GET", fullURL, nil)
 	if err != nil {
-		logStackTrace(err)
+		lob.logStackTrace(err)
 		return err
 	}
 
@@ -167,22 +259,22 @@ func (lob *Lob) Get(endpoint string, params map[string]string, returnValue inter
 	req.Header.Add("Lob-Version", APIVersion)
 	req.Header.Add("Accept", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := lob.httpClient().Do(req)
 	if err != nil {
-		logStackTrace(err)
+		lob.logStackTrace(err)
 		return err
 	}
 	defer resp.Body.Close()
 
 	data, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		logStackTrace(err)
+		lob.logStackTrace(err)
 		return err
 	}
 
 	if resp.StatusCode != 200 {
-		err = fmt.Errorf("Non-200 status code %d returned from %s with body %s", resp.StatusCode, fullURL, data)
-		logStackTrace(err)
+		err = newAPIError(resp.StatusCode, fullURL, data)
+		lob.logStackTrace(err)
 		json.Unmarshal(data, returnValue) // try, anyway -- in case the caller wants error info
 		return err
 	}
@@ -190,10 +282,15 @@ func (lob *Lob) Get(endpoint string, params map[string]string, returnValue inter
 	return json.Unmarshal(data, returnValue)
 }
 
-// Post performs a POST request to the Lob API.
-func (lob *Lob) Post(endpoint string, params map[string]string, returnValue interface{}) error {
+// post performs a single POST attempt to the Lob API, tagged with the given
+// idempotency key. It returns the HTTP status code and Retry-After header
+// alongside any error so that callers can decide whether to retry.
+func (lob *Lob) post(ctx context.Context, endpoint string, params map[string]string, idempotencyKey string, returnValue interface{}) (int, string, error) {
+	ctx, cancel := lob.contextWithTimeout(ctx)
+	defer cancel()
+
 	fullURL := lob.BaseAPI + endpoint
-	log.Debugf("Lob POST %s", fullURL)
+	lob.logger().Debug("Lob POST", "url", fullURL)
 
 	var body io.Reader
 	if params != nil {
@@ -205,10 +302,10 @@ func (lob *Lob) Post(endpoint string, params map[string]string, returnValue inte
 		body = bytes.NewBuffer([]byte(bodyString))
 	}
 
-	req, err := http.NewRequest("POST", fullURL, body)
+	req, err := http.NewRequestWithContext(ctx, "POST", fullURL, body)
 	if err != nil {
-		logStackTrace(err)
-		return err
+		lob.logStackTrace(err)
+		return 0, "", err
 	}
 
 	if body != nil {
@@ -218,38 +315,48 @@ func (lob *Lob) Post(endpoint string, params map[string]string, returnValue inte
 	req.SetBasicAuth(lob.APIKey, "")
 	req.Header.Add("Lob-Version", APIVersion)
 	req.Header.Add("Accept", "application/json")
+	req.Header.Add(IdempotencyKeyHeader, idempotencyKey)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := lob.httpClient().Do(req)
 	if err != nil {
-		logStackTrace(err)
-		return err
+		lob.logStackTrace(err)
+		return 0, "", err
 	}
 	defer resp.Body.Close()
 
 	data, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		logStackTrace(err)
-		return err
+		lob.logStackTrace(err)
+		return resp.StatusCode, "", err
 	}
 
 	if resp.StatusCode != 200 {
-		err = fmt.Errorf("Non-200 status code %d returned from %s with body %s", resp.StatusCode, fullURL, data)
-		logStackTrace(err)
+		err = newAPIError(resp.StatusCode, fullURL, data)
+		lob.logStackTrace(err)
 		json.Unmarshal(data, returnValue) // try, anyway -- in case the caller wants error info
-		return err
+		return resp.StatusCode, resp.Header.Get("Retry-After"), err
 	}
 
-	return json.Unmarshal(data, returnValue)
+	return resp.StatusCode, "", json.Unmarshal(data, returnValue)
 }
 
 // Delete performs a DELETE request to the Lob API.
 func (lob *Lob) Delete(endpoint string, returnValue interface{}) error {
+	return lob.DeleteContext(context.Background(), endpoint, returnValue)
+}
+
+// DeleteContext performs a DELETE request to the Lob API, honoring
+// cancellation and deadlines carried by ctx.
+func (lob *Lob) DeleteContext(ctx context.Context, endpoint string, returnValue interface{}) error {
+	ctx, cancel := lob.contextWithTimeout(ctx)
+	defer cancel()
+
 	fullURL := lob.BaseAPI + endpoint
-	log.Debugf("Lob DELETE %s", fullURL)
+	lob.logger().Debug("Lob DELETE", "url", fullURL)
 
-	req, err := http.NewRequest("DELETE", fullURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fullURL, nil)
 	if err != nil {
-		logStackTrace(err)
+		lob.logStackTrace(err)
 		return err
 	}
 
@@ -257,22 +364,22 @@ func (lob *Lob) Delete(endpoint string, returnValue interface{}) error {
 	req.Header.Add("Lob-Version", APIVersion)
 	req.Header.Add("Accept", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := lob.httpClient().Do(req)
 	if err != nil {
-		logStackTrace(err)
+		lob.logStackTrace(err)
 		return err
 	}
 	defer resp.Body.Close()
 
 	data, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		logStackTrace(err)
+		lob.logStackTrace(err)
 		return err
 	}
 
 	if resp.StatusCode != 200 {
-		err = fmt.Errorf("Non-200 status code %d returned from %s with body %s", resp.StatusCode, fullURL, data)
-		logStackTrace(err)
+		err = newAPIError(resp.StatusCode, fullURL, data)
+		lob.logStackTrace(err)
 		json.Unmarshal(data, returnValue) // try, anyway -- in case the caller wants error info
 		return err
 	}
@@ -0,0 +1,224 @@
+package lob
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+)
+
+// FileUpload describes a single file part for PostMultipart. Build one with
+// NewFileUpload, NewFileUploadFromPath, or NewFileUploadFromURL.
+type FileUpload struct {
+	// Reader supplies the file content. Set by NewFileUpload. Because a
+	// Reader can't be rewound, a FileUpload built this way is not safe to
+	// retry; prefer Path or URL on a Lob with a RetryPolicy configured.
+	Reader io.Reader
+	// Filename is sent as the multipart part's filename. Set by
+	// NewFileUpload and NewFileUploadFromPath.
+	Filename string
+	// ContentType is sent as the multipart part's Content-Type. Optional;
+	// if empty, the part is sent as application/octet-stream.
+	ContentType string
+
+	// Path is a local file the client reads and uploads. Set by
+	// NewFileUploadFromPath.
+	Path string
+
+	// URL is fetched by Lob itself rather than uploaded by the client. Set
+	// by NewFileUploadFromURL. Mutually exclusive with Reader/Path.
+	URL string
+}
+
+// NewFileUpload builds a FileUpload that streams r's content as filename
+// with the given content type (optional).
+func NewFileUpload(r io.Reader, filename, contentType string) FileUpload {
+	return FileUpload{Reader: r, Filename: filename, ContentType: contentType}
+}
+
+// NewFileUploadFromPath builds a FileUpload that reads and uploads the file
+// at path, using its base name as the multipart filename.
+func NewFileUploadFromPath(path string) FileUpload {
+	return FileUpload{Path: path, Filename: filepath.Base(path)}
+}
+
+// NewFileUploadFromURL builds a FileUpload that has Lob fetch the file
+// server-side from url rather than uploading its bytes.
+func NewFileUploadFromURL(url string) FileUpload {
+	return FileUpload{URL: url}
+}
+
+// PostMultipart performs a POST request to the Lob API with a
+// multipart/form-data body, used by endpoints (such as letters and
+// postcards) that accept PDF/HTML file parts alongside form fields. A
+// FileUpload backed by a URL is sent as a plain form value so that Lob
+// fetches it server-side instead of the client uploading bytes. Like Post,
+// it generates an Idempotency-Key and retries according to lob.RetryPolicy.
+func (lob *Lob) PostMultipart(endpoint string, params map[string]string, files map[string]FileUpload, returnValue interface{}) error {
+	return lob.PostMultipartContext(context.Background(), endpoint, params, files, returnValue)
+}
+
+// PostMultipartContext is PostMultipart with an explicit context for
+// cancellation and deadlines.
+func (lob *Lob) PostMultipartContext(ctx context.Context, endpoint string, params map[string]string, files map[string]FileUpload, returnValue interface{}) error {
+	return lob.retryWithIdempotencyKey(ctx, "", lob.RetryPolicy, func(ctx context.Context, key string) (int, string, error) {
+		return lob.postMultipart(ctx, endpoint, params, files, key, returnValue)
+	})
+}
+
+// postMultipart performs a single multipart POST attempt, tagged with the
+// given idempotency key. It returns the HTTP status code and Retry-After
+// header alongside any error so that callers can decide whether to retry.
+func (lob *Lob) postMultipart(ctx context.Context, endpoint string, params map[string]string, files map[string]FileUpload, idempotencyKey string, returnValue interface{}) (int, string, error) {
+	ctx, cancel := lob.contextWithTimeout(ctx)
+	defer cancel()
+
+	fullURL := lob.BaseAPI + endpoint
+
+	body, contentType, err := buildMultipartBody(params, files)
+	if err != nil {
+		lob.logStackTrace(err)
+		return 0, "", err
+	}
+
+	lob.logger().Debug("Lob POST (multipart)", "url", fullURL)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fullURL, body)
+	if err != nil {
+		lob.logStackTrace(err)
+		return 0, "", err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	req.SetBasicAuth(lob.APIKey, "")
+	req.Header.Add("Lob-Version", APIVersion)
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add(IdempotencyKeyHeader, idempotencyKey)
+
+	resp, err := lob.httpClient().Do(req)
+	if err != nil {
+		lob.logStackTrace(err)
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		lob.logStackTrace(err)
+		return resp.StatusCode, "", err
+	}
+
+	if resp.StatusCode != 200 {
+		err = newAPIError(resp.StatusCode, fullURL, data)
+		lob.logStackTrace(err)
+		json.Unmarshal(data, returnValue) // try, anyway -- in case the caller wants error info
+		return resp.StatusCode, resp.Header.Get("Retry-After"), err
+	}
+
+	return resp.StatusCode, "", json.Unmarshal(data, returnValue)
+}
+
+// buildMultipartBody writes params and files into a multipart/form-data
+// body, returning the body and its Content-Type header value.
+func buildMultipartBody(params map[string]string, files map[string]FileUpload) (io.Reader, string, error) {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+
+	for k, v := range params {
+		if err := w.WriteField(k, v); err != nil {
+			return nil, "", fmt.Errorf("writing field %q: %w", k, err)
+		}
+	}
+
+	for field, file := range files {
+		if err := validateFileUpload(file); err != nil {
+			return nil, "", fmt.Errorf("field %q: %w", field, err)
+		}
+
+		if file.URL != "" {
+			if err := w.WriteField(field, file.URL); err != nil {
+				return nil, "", fmt.Errorf("writing file URL field %q: %w", field, err)
+			}
+			continue
+		}
+
+		reader := file.Reader
+		filename := file.Filename
+		if file.Path != "" {
+			f, err := os.Open(file.Path)
+			if err != nil {
+				return nil, "", fmt.Errorf("opening %q: %w", file.Path, err)
+			}
+			defer f.Close()
+			reader = f
+			if filename == "" {
+				filename = filepath.Base(file.Path)
+			}
+		}
+
+		part, err := createFormFile(w, field, filename, file.ContentType)
+		if err != nil {
+			return nil, "", fmt.Errorf("creating form file %q: %w", field, err)
+		}
+		if _, err := io.Copy(part, reader); err != nil {
+			return nil, "", fmt.Errorf("copying file %q: %w", field, err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return body, w.FormDataContentType(), nil
+}
+
+// validateFileUpload rejects a FileUpload that sets none, or more than one,
+// of Reader, Path, and URL — an omission that would otherwise reach
+// io.Copy with a nil reader and panic.
+func validateFileUpload(f FileUpload) error {
+	set := 0
+	if f.Reader != nil {
+		set++
+	}
+	if f.Path != "" {
+		set++
+	}
+	if f.URL != "" {
+		set++
+	}
+
+	switch set {
+	case 0:
+		return errors.New("exactly one of Reader, Path, or URL must be set, got none")
+	case 1:
+		return nil
+	default:
+		return errors.New("exactly one of Reader, Path, or URL must be set, got more than one")
+	}
+}
+
+// isFileUploadSet reports whether f has any of Reader, Path, or URL set.
+func isFileUploadSet(f FileUpload) bool {
+	return f.Reader != nil || f.Path != "" || f.URL != ""
+}
+
+// createFormFile is multipart.Writer.CreateFormFile with an explicit
+// Content-Type instead of the "application/octet-stream" default it
+// assumes when contentType is empty.
+func createFormFile(w *multipart.Writer, field, filename, contentType string) (io.Writer, error) {
+	if contentType == "" {
+		return w.CreateFormFile(field, filename)
+	}
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, field, filename))
+	h.Set("Content-Type", contentType)
+	return w.CreatePart(h)
+}
@@ -0,0 +1,53 @@
+package lob
+
+import (
+	"context"
+	"time"
+)
+
+// Postcard represents a postcard created through the Lob API.
+type Postcard struct {
+	ID                   string            `json:"id"`
+	Description          string            `json:"description"`
+	To                   string            `json:"to"`
+	From                 string            `json:"from"`
+	URL                  string            `json:"url"`
+	ExpectedDeliveryDate string            `json:"expected_delivery_date"`
+	Metadata             map[string]string `json:"metadata"`
+}
+
+// CreatePostcardParams are the form fields accepted when creating a
+// postcard. Front is required and holds the postcard's front artwork; Back
+// is optional. See PostMultipart.
+type CreatePostcardParams struct {
+	Description string            `json:"description"`
+	To          string            `json:"to"`
+	From        string            `json:"from"`
+	Metadata    map[string]string `json:"metadata"`
+	Front       FileUpload        `json:"-"`
+	Back        FileUpload        `json:"-"`
+}
+
+// CreatePostcard creates a postcard from params, uploading params.Front and,
+// if set, params.Back as the postcard's artwork.
+func (lob *Lob) CreatePostcard(params CreatePostcardParams) (*Postcard, error) {
+	return lob.CreatePostcardContext(context.Background(), params)
+}
+
+// CreatePostcardContext is CreatePostcard with an explicit context for
+// cancellation and deadlines.
+func (lob *Lob) CreatePostcardContext(ctx context.Context, params CreatePostcardParams) (*Postcard, error) {
+	start := time.Now()
+	formParams := json2form(params)
+	files := map[string]FileUpload{"front": params.Front}
+	if isFileUploadSet(params.Back) {
+		files["back"] = params.Back
+	}
+	var postcard Postcard
+	err := lob.PostMultipartContext(ctx, "postcards", formParams, files, &postcard)
+	Metrics.CreatePostcard.Observe(time.Since(start), err == nil)
+	if err != nil {
+		return nil, err
+	}
+	return &postcard, nil
+}
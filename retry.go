@@ -0,0 +1,226 @@
+package lob
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKeyHeader is the HTTP header Lob uses to detect duplicate
+// POST requests. See https://docs.lob.com/#tag/Idempotency.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// RetryPolicy controls how a Lob retries failed POST requests.
+//
+// The zero value disables retries: MaxAttempts of 0 means the request is
+// attempted exactly once.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Zero or one means no retries are performed.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry. Subsequent retries
+	// back off exponentially from this value.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the computed backoff delay, including any Retry-After
+	// header. Zero means no cap.
+	MaxDelay time.Duration
+
+	// Jitter is the maximum fraction of the computed delay, in the range
+	// [0, 1], added or subtracted at random to avoid thundering herds.
+	Jitter float64
+
+	// RetryableStatusCodes lists the HTTP status codes that should be
+	// retried. If nil, DefaultRetryableStatusCodes is used.
+	RetryableStatusCodes []int
+}
+
+// DefaultRetryableStatusCodes are retried when no RetryableStatusCodes are
+// configured on the RetryPolicy: 429 (rate limited) and the 5xx range.
+var DefaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+func (p *RetryPolicy) retryable(statusCode int) bool {
+	if p == nil {
+		return false
+	}
+	if statusCode == 0 {
+		// No HTTP response was received at all (connection reset, dial
+		// timeout, client-side deadline, ...) — this is exactly the
+		// transient failure retries exist for.
+		return true
+	}
+	codes := p.RetryableStatusCodes
+	if codes == nil {
+		codes = DefaultRetryableStatusCodes
+	}
+	for _, c := range codes {
+		if c == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// maxBackoffShift caps the exponent in delay's 1<<(attempt-1), and delay
+// saturates the product at the largest time.Duration, so that the
+// multiplication against BaseDelay can't overflow time.Duration into a
+// negative number, which would make time.After fire immediately instead of
+// backing off.
+const maxBackoffShift = 20
+
+// delay computes how long to wait before the given retry attempt (1 for
+// the first retry, 2 for the second, ...), honoring retryAfter when the
+// server supplied one.
+func (p *RetryPolicy) delay(attempt int, retryAfter string) time.Duration {
+	d := p.BaseDelay
+	if d <= 0 {
+		d = time.Second
+	}
+
+	shift := attempt - 1
+	if shift > maxBackoffShift {
+		shift = maxBackoffShift
+	}
+	if d > math.MaxInt64>>uint(shift) {
+		d = math.MaxInt64
+	} else {
+		d = d * time.Duration(1<<uint(shift))
+	}
+
+	if ra, ok := parseRetryAfter(retryAfter); ok && ra > d {
+		d = ra
+	}
+
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+
+	if p.Jitter > 0 {
+		jitter := float64(d) * p.Jitter
+		d = d + time.Duration((rand.Float64()*2-1)*jitter)
+		if d < 0 {
+			d = 0
+		}
+	}
+
+	// Re-apply the cap: jitter can push d back above MaxDelay, which must
+	// bound the delay Lob actually waits, not just the pre-jitter value.
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+
+	return d
+}
+
+// parseRetryAfter parses an HTTP Retry-After header value, which may be
+// either a number of seconds or an HTTP-date.
+func parseRetryAfter(retryAfter string) (time.Duration, bool) {
+	if retryAfter == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(retryAfter); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(retryAfter); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
+
+// PostOptions carries per-call overrides for PostWithOptions.
+type PostOptions struct {
+	// IdempotencyKey, if non-empty, is sent as the Idempotency-Key header
+	// and reused across retries. If empty, Post and PostWithOptions
+	// generate a random one so that automatic retries are safe to replay.
+	IdempotencyKey string
+
+	// RetryPolicy, if non-nil, overrides the Lob's RetryPolicy for this
+	// call.
+	RetryPolicy *RetryPolicy
+}
+
+// Post performs a POST request to the Lob API, automatically generating an
+// Idempotency-Key and retrying according to lob.RetryPolicy.
+func (lob *Lob) Post(endpoint string, params map[string]string, returnValue interface{}) error {
+	return lob.PostWithOptions(context.Background(), endpoint, params, returnValue, PostOptions{})
+}
+
+// PostContext performs a POST request to the Lob API, honoring cancellation
+// and deadlines carried by ctx.
+func (lob *Lob) PostContext(ctx context.Context, endpoint string, params map[string]string, returnValue interface{}) error {
+	return lob.PostWithOptions(ctx, endpoint, params, returnValue, PostOptions{})
+}
+
+// PostWithOptions performs a POST request to the Lob API with explicit
+// control over the idempotency key and retry policy used for this call.
+func (lob *Lob) PostWithOptions(ctx context.Context, endpoint string, params map[string]string, returnValue interface{}, opts PostOptions) error {
+	policy := opts.RetryPolicy
+	if policy == nil {
+		policy = lob.RetryPolicy
+	}
+
+	return lob.retryWithIdempotencyKey(ctx, opts.IdempotencyKey, policy, func(ctx context.Context, key string) (int, string, error) {
+		return lob.post(ctx, endpoint, params, key, returnValue)
+	})
+}
+
+// retryWithIdempotencyKey generates an idempotency key (reusing key if
+// non-empty) and calls attempt with it, retrying according to policy. Any
+// POST-like call that must be safe to replay under lob.RetryPolicy should
+// be routed through this, not just Post.
+func (lob *Lob) retryWithIdempotencyKey(ctx context.Context, key string, policy *RetryPolicy, attempt func(ctx context.Context, key string) (statusCode int, retryAfter string, err error)) error {
+	if key == "" {
+		key = uuid.NewString()
+	}
+
+	var lastErr error
+	attempts := policy.maxAttempts()
+	for i := 1; i <= attempts; i++ {
+		statusCode, retryAfter, err := attempt(ctx, key)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+
+		if i == attempts || !policy.retryable(statusCode) {
+			return lastErr
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(policy.delay(i, retryAfter)):
+		}
+	}
+
+	return lastErr
+}
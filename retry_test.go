@@ -0,0 +1,124 @@
+package lob
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyMaxAttempts(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *RetryPolicy
+		want int
+	}{
+		{"nil policy", nil, 1},
+		{"zero value", &RetryPolicy{}, 1},
+		{"negative", &RetryPolicy{MaxAttempts: -1}, 1},
+		{"configured", &RetryPolicy{MaxAttempts: 5}, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.maxAttempts(); got != tt.want {
+				t.Errorf("maxAttempts() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyRetryable(t *testing.T) {
+	p := &RetryPolicy{}
+
+	if !p.retryable(0) {
+		t.Error("a transport-level failure (status 0) should always be retryable")
+	}
+	if !p.retryable(http.StatusTooManyRequests) {
+		t.Error("429 should be retryable by default")
+	}
+	if !p.retryable(http.StatusServiceUnavailable) {
+		t.Error("503 should be retryable by default")
+	}
+	if p.retryable(http.StatusBadRequest) {
+		t.Error("400 should not be retryable by default")
+	}
+
+	var nilPolicy *RetryPolicy
+	if nilPolicy.retryable(http.StatusServiceUnavailable) {
+		t.Error("a nil policy should never be retryable")
+	}
+
+	custom := &RetryPolicy{RetryableStatusCodes: []int{http.StatusBadRequest}}
+	if !custom.retryable(http.StatusBadRequest) {
+		t.Error("custom RetryableStatusCodes should be honored")
+	}
+	if custom.retryable(http.StatusTooManyRequests) {
+		t.Error("custom RetryableStatusCodes should replace, not extend, the default list")
+	}
+}
+
+func TestRetryPolicyDelayExponentialBackoff(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: time.Second}
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second}
+	for i, attempt := range []int{1, 2, 3, 4} {
+		if got := p.delay(attempt, ""); got != want[i] {
+			t.Errorf("delay(%d, \"\") = %v, want %v", attempt, got, want[i])
+		}
+	}
+}
+
+func TestRetryPolicyDelayDoesNotOverflowNegative(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: time.Second}
+
+	// A very large attempt count used to overflow time.Duration into a
+	// negative number via 1<<uint(attempt-1).
+	for _, attempt := range []int{30, 62, 63, 64, 1000} {
+		if d := p.delay(attempt, ""); d < 0 {
+			t.Errorf("delay(%d, \"\") = %v, want a non-negative duration", attempt, d)
+		}
+	}
+}
+
+func TestRetryPolicyDelayMaxDelayCap(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: time.Second, MaxDelay: 3 * time.Second}
+
+	if got := p.delay(10, ""); got != 3*time.Second {
+		t.Errorf("delay(10, \"\") = %v, want capped at %v", got, 3*time.Second)
+	}
+}
+
+func TestRetryPolicyDelayMaxDelayCapAppliesAfterJitter(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: time.Second, MaxDelay: 3 * time.Second, Jitter: 1}
+
+	for i := 0; i < 50; i++ {
+		if got := p.delay(10, ""); got > 3*time.Second {
+			t.Fatalf("delay(10, \"\") = %v, want capped at %v even after jitter", got, 3*time.Second)
+		}
+	}
+}
+
+func TestRetryPolicyDelayRetryAfterSeconds(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: time.Second}
+
+	if got := p.delay(1, "5"); got != 5*time.Second {
+		t.Errorf("delay(1, \"5\") = %v, want %v", got, 5*time.Second)
+	}
+}
+
+func TestRetryPolicyDelayRetryAfterHTTPDate(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: time.Second}
+	future := time.Now().Add(10 * time.Second)
+
+	got := p.delay(1, future.UTC().Format(http.TimeFormat))
+	if got < 8*time.Second || got > 10*time.Second {
+		t.Errorf("delay with an HTTP-date Retry-After = %v, want roughly 10s", got)
+	}
+}
+
+func TestRetryPolicyDelayIgnoresGarbageRetryAfter(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: time.Second}
+
+	if got := p.delay(1, "not-a-valid-value"); got != time.Second {
+		t.Errorf("delay(1, garbage) = %v, want the base delay %v", got, time.Second)
+	}
+}
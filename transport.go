@@ -0,0 +1,103 @@
+package lob
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware wraps an http.RoundTripper with additional behavior. Chains
+// of Middleware are built with Chain and installed via Lob.Transport.
+type Middleware func(http.RoundTripper) http.RoundTripper
+
+// roundTripperFunc adapts a function to the http.RoundTripper interface.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Chain composes middlewares into a single Middleware, applied in the order
+// given: the first middleware wraps closest to the underlying transport, so
+// it sees the request last and the response first.
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		for i := 0; i < len(middlewares); i++ {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
+
+// redactedHeaders maps each header whose value is replaced in logged
+// requests to the placeholder logged in its place.
+var redactedHeaders = map[string]string{
+	"Authorization": "REDACTED",
+}
+
+// LoggingMiddleware logs each request's method and URL and each response's
+// status code and duration to logger, redacting the Authorization header.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			headers := req.Header.Clone()
+			for name, redacted := range redactedHeaders {
+				if headers.Get(name) != "" {
+					headers.Set(name, redacted)
+				}
+			}
+
+			start := time.Now()
+			logger.Debug("lob http request", "method", req.Method, "url", req.URL.String(), "headers", headers)
+
+			resp, err := next.RoundTrip(req)
+			duration := time.Since(start)
+			if err != nil {
+				logger.Error("lob http request failed", "method", req.Method, "url", req.URL.String(), "duration", duration, "error", err)
+				return resp, err
+			}
+
+			logger.Debug("lob http response", "method", req.Method, "url", req.URL.String(), "status", resp.StatusCode, "duration", duration)
+			return resp, err
+		})
+	}
+}
+
+// tracer is the OpenTelemetry tracer used by TracingMiddleware.
+var tracer = otel.Tracer("github.com/sezzle/go-lob")
+
+// TracingMiddleware starts an OpenTelemetry span around each request, named
+// after the HTTP method and URL path, recording the status code and any
+// error.
+func TracingMiddleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			ctx, span := tracer.Start(req.Context(), req.Method+" "+req.URL.Path,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					attribute.String("http.method", req.Method),
+					attribute.String("http.url", req.URL.String()),
+				),
+			)
+			defer span.End()
+
+			resp, err := next.RoundTrip(req.WithContext(ctx))
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return resp, err
+			}
+
+			span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+			if resp.StatusCode >= 400 {
+				span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+			}
+			return resp, err
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package webhook
+
+import "net/http"
+
+// NewHandler returns an http.Handler that verifies each inbound request
+// against secret and calls dispatch with the resulting Event. Requests that
+// fail verification receive a 400 response and are not dispatched.
+func NewHandler(secret string, dispatch func(*Event)) http.Handler {
+	verifier := NewVerifier(secret)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event, err := verifier.Verify(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		dispatch(event)
+		w.WriteHeader(http.StatusOK)
+	})
+}
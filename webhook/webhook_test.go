@@ -0,0 +1,100 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const testSecret = "whsec_test"
+
+func sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newSignedRequest(secret string, ts time.Time, body []byte) *http.Request {
+	timestamp := strconv.FormatInt(ts.UnixMilli(), 10)
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	req.Header.Set("Lob-Signature", sign(secret, timestamp, body))
+	req.Header.Set("Lob-Signature-Timestamp", timestamp)
+	return req
+}
+
+func TestVerifyValidSignature(t *testing.T) {
+	body := []byte(`{"id":"evt_123","event_type":{"id":"letter.created","resource_type":"letter"},"body":{"id":"ltr_123"}}`)
+	req := newSignedRequest(testSecret, time.Now(), body)
+
+	v := NewVerifier(testSecret)
+	event, err := v.Verify(req)
+	if err != nil {
+		t.Fatalf("Verify returned error for a validly signed request: %v", err)
+	}
+	if event.ID != "evt_123" {
+		t.Errorf("event.ID = %q, want %q", event.ID, "evt_123")
+	}
+	if event.EventType.ResourceType != "letter" {
+		t.Errorf("event.EventType.ResourceType = %q, want %q", event.EventType.ResourceType, "letter")
+	}
+}
+
+func TestVerifyTamperedBody(t *testing.T) {
+	body := []byte(`{"id":"evt_123"}`)
+	// Sign one body but send another, simulating tampering in transit.
+	req := newSignedRequestWithMismatchedBody(testSecret, time.Now(), body, []byte(`{"id":"evt_999"}`))
+
+	v := NewVerifier(testSecret)
+	if _, err := v.Verify(req); err != ErrInvalidSignature {
+		t.Fatalf("Verify error = %v, want %v", err, ErrInvalidSignature)
+	}
+}
+
+// newSignedRequestWithMismatchedBody signs signedBody but sends sentBody,
+// simulating a request tampered with in transit.
+func newSignedRequestWithMismatchedBody(secret string, ts time.Time, signedBody, sentBody []byte) *http.Request {
+	timestamp := strconv.FormatInt(ts.UnixMilli(), 10)
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(sentBody))
+	req.Header.Set("Lob-Signature", sign(secret, timestamp, signedBody))
+	req.Header.Set("Lob-Signature-Timestamp", timestamp)
+	return req
+}
+
+func TestVerifyMissingSignatureHeader(t *testing.T) {
+	body := []byte(`{"id":"evt_123"}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	req.Header.Set("Lob-Signature-Timestamp", strconv.FormatInt(time.Now().UnixMilli(), 10))
+
+	v := NewVerifier(testSecret)
+	if _, err := v.Verify(req); err != ErrMissingSignature {
+		t.Fatalf("Verify error = %v, want %v", err, ErrMissingSignature)
+	}
+}
+
+func TestVerifyOutOfSkewTimestamp(t *testing.T) {
+	body := []byte(`{"id":"evt_123"}`)
+	req := newSignedRequest(testSecret, time.Now().Add(-time.Hour), body)
+
+	v := NewVerifier(testSecret)
+	if _, err := v.Verify(req); err != ErrTimestampOutOfRange {
+		t.Fatalf("Verify error = %v, want %v", err, ErrTimestampOutOfRange)
+	}
+}
+
+func TestVerifyCustomMaxSkew(t *testing.T) {
+	body := []byte(`{"id":"evt_123"}`)
+	req := newSignedRequest(testSecret, time.Now().Add(-time.Hour), body)
+
+	v := &Verifier{Secret: testSecret, MaxSkew: 2 * time.Hour}
+	if _, err := v.Verify(req); err != nil {
+		t.Fatalf("Verify returned error with a widened skew window: %v", err)
+	}
+}